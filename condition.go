@@ -0,0 +1,148 @@
+package di
+
+import "reflect"
+
+type (
+	// Condition 决定一个conditional bean是否参与注册。Matches在所有bean定义收集完成后、
+	// 实例化开始前被求值一次
+	Condition interface {
+		Matches(env ConditionContext) bool
+	}
+
+	// ConditionContext 提供Condition求值时需要访问的容器状态
+	ConditionContext interface {
+		Property() ValueStore
+		HasBean(beanName string) bool
+		HasProfile(profile string) bool
+	}
+
+	onPropertyCondition struct {
+		key      string
+		expected interface{}
+	}
+
+	onBeanCondition struct {
+		beanName string
+	}
+
+	onMissingBeanCondition struct {
+		beanName string
+	}
+
+	onProfileCondition struct {
+		profile string
+	}
+)
+
+// OnProperty 当valueStore中key对应的值存在且等于expected时条件成立
+func OnProperty(key string, expected interface{}) Condition {
+	return onPropertyCondition{key: key, expected: expected}
+}
+
+func (c onPropertyCondition) Matches(env ConditionContext) bool {
+	value, ok := env.Property().Get(c.key)
+	if !ok {
+		return false
+	}
+	return reflect.DeepEqual(value, c.expected)
+}
+
+// OnBean 当名为beanName的bean已被注册(Provide/RegisterBean/ProvideFunc)时条件成立
+func OnBean(beanName string) Condition {
+	return onBeanCondition{beanName: beanName}
+}
+
+func (c onBeanCondition) Matches(env ConditionContext) bool {
+	return env.HasBean(c.beanName)
+}
+
+// OnMissingBean 当名为beanName的bean尚未被注册时条件成立，常用于提供默认实现
+func OnMissingBean(beanName string) Condition {
+	return onMissingBeanCondition{beanName: beanName}
+}
+
+func (c onMissingBeanCondition) Matches(env ConditionContext) bool {
+	return !env.HasBean(c.beanName)
+}
+
+// OnProfile 当容器通过Profiles激活了profile时条件成立
+func OnProfile(profile string) Condition {
+	return onProfileCondition{profile: profile}
+}
+
+func (c onProfileCondition) Matches(env ConditionContext) bool {
+	return env.HasProfile(c.profile)
+}
+
+// ProvideIf 注册一个conditional bean：仅当cond.Matches在Load()两阶段解析的第一阶段返回true时，
+// prototype才会参与实例化，否则该bean定义会在实例化前被整体丢弃
+func (di *DI) ProvideIf(cond Condition, prototype interface{}) *DI {
+	return di.ProvideIfWithBeanName(cond, "", prototype)
+}
+
+// ProvideIfWithBeanName 与ProvideIf相同，但允许指定beanName
+func (di *DI) ProvideIfWithBeanName(cond Condition, beanName string, prototype interface{}) *DI {
+	di.ProvideWithBeanName(beanName, prototype)
+	if beanName == "" {
+		beanName = GetBeanName(prototype)
+	}
+	di.conditionMap[beanName] = cond
+	return di
+}
+
+// Profiles 设置容器当前激活的profile，用于OnProfile条件判断
+func (di *DI) Profiles(names ...string) *DI {
+	for _, name := range names {
+		di.profiles[name] = true
+	}
+	return di
+}
+
+// HasBean 返回beanName是否已被注册（不论是否已实例化），供Condition判断OnBean/OnMissingBean使用
+func (di *DI) HasBean(beanName string) bool {
+	if _, ok := di.beanMap[beanName]; ok {
+		return true
+	}
+	if _, ok := di.beanDefinitionMap[beanName]; ok {
+		return true
+	}
+	_, ok := di.ctorMap[beanName]
+	return ok
+}
+
+// HasProfile 返回profile是否已通过Profiles激活
+func (di *DI) HasProfile(profile string) bool {
+	return di.profiles[profile]
+}
+
+// applyConditions 在所有bean定义收集完成后，按conditionMap过滤beanDefinitionMap：
+// 不满足条件的bean定义会在实例化前被整体丢弃，不参与后续的aware/生命周期流程。
+// 所有Matches()调用都针对过滤前的完整定义集合求值（按orderedBeanNames确定顺序遍历），
+// 求值完成后才统一执行删除，避免OnBean/OnMissingBean的判断结果受map遍历顺序或
+// 其他conditional bean是否已被删除的影响
+func (di *DI) applyConditions() {
+	var toRemove []string
+	for _, beanName := range di.orderedBeanNames {
+		cond, ok := di.conditionMap[beanName]
+		if !ok {
+			continue
+		}
+		if !cond.Matches(di) {
+			toRemove = append(toRemove, beanName)
+		}
+	}
+	for _, beanName := range toRemove {
+		delete(di.beanDefinitionMap, beanName)
+		delete(di.scopeMap, beanName)
+		di.removeOrderedBeanName(beanName)
+	}
+}
+
+func (di *DI) removeOrderedBeanName(beanName string) {
+	for i, name := range di.orderedBeanNames {
+		if name == beanName {
+			di.orderedBeanNames = append(di.orderedBeanNames[:i], di.orderedBeanNames[i+1:]...)
+			return
+		}
+	}
+}
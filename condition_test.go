@@ -0,0 +1,38 @@
+package di
+
+import "testing"
+
+type primaryGreeterImpl struct{}
+
+type fallbackGreeterImpl struct{}
+
+type dependentOnMissingOrder struct{}
+
+// TestApplyConditions_OnBeanAndOnMissingBeanAgainstPresentBean 验证目标bean被无条件注册时，
+// OnBean条件的bean被保留，OnMissingBean条件的bean在实例化前被丢弃
+func TestApplyConditions_OnBeanAndOnMissingBeanAgainstPresentBean(t *testing.T) {
+	container := New()
+	container.Provide(&primaryGreeterImpl{})
+	container.ProvideIfWithBeanName(OnBean("primaryGreeterImpl"), "dependentOnMissingOrder", &dependentOnMissingOrder{})
+	container.ProvideIfWithBeanName(OnMissingBean("primaryGreeterImpl"), "fallbackGreeterImpl", &fallbackGreeterImpl{})
+	container.Load()
+
+	if _, ok := container.GetBean("dependentOnMissingOrder"); !ok {
+		t.Fatalf("expected dependentOnMissingOrder to be registered since primaryGreeterImpl is present")
+	}
+	if _, ok := container.GetBean("fallbackGreeterImpl"); ok {
+		t.Fatalf("expected fallbackGreeterImpl to be dropped since primaryGreeterImpl is present")
+	}
+}
+
+// TestApplyConditions_OnMissingBeanRegistersWhenTargetAbsent 确认OnMissingBean在目标bean
+// 确实缺失时让条件bean参与注册
+func TestApplyConditions_OnMissingBeanRegistersWhenTargetAbsent(t *testing.T) {
+	container := New()
+	container.ProvideIfWithBeanName(OnMissingBean("primaryGreeterImpl"), "fallbackGreeterImpl", &fallbackGreeterImpl{})
+	container.Load()
+
+	if _, ok := container.GetBean("fallbackGreeterImpl"); !ok {
+		t.Fatalf("expected fallbackGreeterImpl to be registered since primaryGreeterImpl is absent")
+	}
+}
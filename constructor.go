@@ -0,0 +1,156 @@
+package di
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// ctorDependency 描述构造函数的一个参数：按类型解析，或通过参数结构体字段上的 di:"name" 标签按名称解析
+type ctorDependency struct {
+	name string
+	typ  reflect.Type
+}
+
+// ctorDefinition 记录一个通过ProvideFunc注册的构造函数及其参数依赖
+type ctorDefinition struct {
+	beanName     string
+	fn           reflect.Value
+	deps         []ctorDependency
+	paramsStruct bool // true表示构造函数签名为 func(params SomeParams) (T, error)，deps来自params的字段
+}
+
+var errType = reflect.TypeOf((*error)(nil)).Elem()
+
+// ProvideFunc 注册一个构造函数作为bean提供者，ctor签名必须是 func(deps...) T 或 func(deps...) (T, error)，
+// 其中T为指针类型。deps按参数类型从容器中解析；若ctor只有一个struct类型参数，则按该struct字段上的
+// di:"name" 标签按名称解析，便于在多个同类型依赖中指定具体bean
+func (di *DI) ProvideFunc(beanName string, ctor interface{}) *DI {
+	if di.loaded {
+		panic(ErrLoaded)
+	}
+	fnType := reflect.TypeOf(ctor)
+	if fnType == nil || fnType.Kind() != reflect.Func {
+		panic(fmt.Errorf("%w: ProvideFunc requires a constructor function", ErrBean))
+	}
+	if fnType.NumOut() != 1 && fnType.NumOut() != 2 {
+		panic(fmt.Errorf("%w: constructor must return (T) or (T, error)", ErrBean))
+	}
+	if fnType.NumOut() == 2 && fnType.Out(1) != errType {
+		panic(fmt.Errorf("%w: constructor's second return value must be error", ErrBean))
+	}
+	resultType := fnType.Out(0)
+	if resultType.Kind() != reflect.Ptr {
+		panic(fmt.Errorf("%w: constructor must return a pointer bean", ErrBean))
+	}
+	if beanName == "" {
+		beanName = GetBeanName(reflect.New(resultType.Elem()).Interface())
+	}
+	if _, exist := di.beanMap[beanName]; exist {
+		panic(fmt.Errorf("%w: bean %s already exists", ErrBean, beanName))
+	}
+	if _, exist := di.beanDefinitionMap[beanName]; exist {
+		panic(fmt.Errorf("%w: bean %s already exists", ErrBean, beanName))
+	}
+	if _, exist := di.ctorMap[beanName]; exist {
+		panic(fmt.Errorf("%w: bean %s already defined by constructor", ErrDefinition, beanName))
+	}
+	di.ctorMap[beanName] = &ctorDefinition{
+		beanName:     beanName,
+		fn:           reflect.ValueOf(ctor),
+		deps:         parseCtorDeps(fnType),
+		paramsStruct: isParamsStruct(fnType),
+	}
+	di.orderedBeanNames = append(di.orderedBeanNames, beanName)
+	return di
+}
+
+func isParamsStruct(fnType reflect.Type) bool {
+	return fnType.NumIn() == 1 && fnType.In(0).Kind() == reflect.Struct
+}
+
+func parseCtorDeps(fnType reflect.Type) []ctorDependency {
+	if isParamsStruct(fnType) {
+		paramsType := fnType.In(0)
+		deps := make([]ctorDependency, paramsType.NumField())
+		for i := 0; i < paramsType.NumField(); i++ {
+			field := paramsType.Field(i)
+			deps[i] = ctorDependency{name: field.Tag.Get("di"), typ: field.Type}
+		}
+		return deps
+	}
+	deps := make([]ctorDependency, fnType.NumIn())
+	for i := 0; i < fnType.NumIn(); i++ {
+		deps[i] = ctorDependency{typ: fnType.In(i)}
+	}
+	return deps
+}
+
+// buildConstructors 对所有通过ProvideFunc注册的bean按依赖关系做拓扑排序后依次构建，
+// 构建出的bean会参与BeanConstruct/AfterPropertiesSet/Initialized生命周期回调
+func (di *DI) buildConstructors() {
+	built := map[string]bool{}
+	var build func(beanName string, chain []string)
+	build = func(beanName string, chain []string) {
+		if built[beanName] {
+			return
+		}
+		ctorDef, ok := di.ctorMap[beanName]
+		if !ok {
+			return
+		}
+		if di.building[beanName] {
+			panic(fmt.Errorf("%w: circular constructor dependency detected: %s", ErrBean, strings.Join(append(chain, beanName), " -> ")))
+		}
+		di.building[beanName] = true
+		nextChain := append(append([]string{}, chain...), beanName)
+		args := make([]reflect.Value, len(ctorDef.deps))
+		for i, dep := range ctorDef.deps {
+			depName := dep.name
+			if depName == "" {
+				names := di.beanNamesByType(dep.typ)
+				if len(names) == 0 {
+					panic(fmt.Errorf("%w: no bean found for constructor param %s of %s", ErrBean, dep.typ.String(), beanName))
+				}
+				if len(names) > 1 {
+					panic(fmt.Errorf("%w: ambiguous constructor param %s of %s matches beans %v, specify di:\"name\"", ErrBean, dep.typ.String(), beanName, names))
+				}
+				depName = names[0]
+			}
+			build(depName, nextChain)
+			depBean, ok := di.resolveDependency(depName, false)
+			if !ok {
+				panic(fmt.Errorf("%w: %s notfound for constructor %s", ErrBean, depName, beanName))
+			}
+			args[i] = reflect.ValueOf(depBean)
+		}
+		in := args
+		if ctorDef.paramsStruct {
+			params := reflect.New(ctorDef.fn.Type().In(0)).Elem()
+			for i := range args {
+				params.Field(i).Set(args[i])
+			}
+			in = []reflect.Value{params}
+		}
+		out := ctorDef.fn.Call(in)
+		if len(out) == 2 && !out[1].IsNil() {
+			panic(fmt.Errorf("%w: constructor for %s failed: %v", ErrBean, beanName, out[1].Interface()))
+		}
+		bean := out[0].Interface()
+		if construct, ok := bean.(BeanConstruct); ok {
+			construct.BeanConstruct()
+		}
+		if propertiesSet, ok := bean.(AfterPropertiesSet); ok {
+			propertiesSet.AfterPropertiesSet()
+		}
+		if initialized, ok := bean.(Initialized); ok {
+			initialized.Initialized()
+		}
+		di.beanMap[beanName] = bean
+		delete(di.building, beanName)
+		built[beanName] = true
+	}
+	for beanName := range di.ctorMap {
+		build(beanName, nil)
+	}
+}
@@ -0,0 +1,100 @@
+package di
+
+import (
+	"reflect"
+	"unicode"
+)
+
+// definition 描述一个通过Provide/ProvideWithBeanName登记的bean：具体类型及其aware字段清单
+type definition struct {
+	beanName string
+	Type     reflect.Type
+	awareMap map[string]awareInfo // 字段名:该字段的aware注入描述
+}
+
+// awareInfo 描述一个标注了 aware 结构体tag的字段应如何被注入
+type awareInfo struct {
+	beanName   string         // 单个bean场景下按名称解析的目标beanName，collection不为collectionNone时不使用
+	beanType   reflect.Type   // 单个bean场景下为字段类型本身；集合场景下为集合元素类型
+	isPtr      bool           // beanType是否为指针类型，决定按AssignableTo还是Implements做类型校验
+	collection collectionKind // 字段是单个bean、[]Interface还是map[string]Interface
+	scope      Scope          // 注入字段声明的作用域，默认ScopeSingleton
+}
+
+// newDefinition 反射扫描prototype的直接字段，收集所有标注了 aware tag 的字段生成awareMap
+func newDefinition(beanName string, prototype reflect.Type) definition {
+	def := definition{beanName: beanName, Type: prototype, awareMap: map[string]awareInfo{}}
+	for i := 0; i < prototype.NumField(); i++ {
+		field := prototype.Field(i)
+		tagValue, ok := field.Tag.Lookup("aware")
+		if !ok {
+			continue
+		}
+		def.awareMap[field.Name] = parseAwareInfo(field, tagValue)
+	}
+	return def
+}
+
+// parseAwareInfo 根据字段类型与aware/scope标签值构建awareInfo：
+// []SomeInterface / map[string]SomeInterface 形式的字段按类型收集为bean集合；
+// 其余字段按名称解析单个bean，名称取自aware标签值，留空时取字段类型派生的默认beanName。
+// 单个bean字段上的 scope:"prototype" 标签会转化为forceFresh：即使目标bean本身是单例注册的，
+// 该注入点每次仍会构建一个全新实例，集合字段不支持该标签
+func parseAwareInfo(field reflect.StructField, tagValue string) awareInfo {
+	var info awareInfo
+	switch field.Type.Kind() {
+	case reflect.Slice:
+		info.collection = collectionSlice
+		info.beanType = field.Type.Elem()
+		info.isPtr = info.beanType.Kind() == reflect.Ptr
+	case reflect.Map:
+		info.collection = collectionMap
+		info.beanType = field.Type.Elem()
+		info.isPtr = info.beanType.Kind() == reflect.Ptr
+	default:
+		info.collection = collectionNone
+		info.beanType = field.Type
+		info.isPtr = field.Type.Kind() == reflect.Ptr
+		if tagValue != "" {
+			info.beanName = tagValue
+		} else {
+			info.beanName = defaultAwareBeanName(field.Type)
+		}
+		if scopeTag, ok := field.Tag.Lookup("scope"); ok && scopeTag == "prototype" {
+			info.scope = ScopePrototype
+		}
+	}
+	return info
+}
+
+// defaultAwareBeanName 由字段类型派生默认beanName，规则与GetBeanName一致：指针类型取其指向类型的名称
+func defaultAwareBeanName(t reflect.Type) string {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return lowerFirst(t.Name())
+}
+
+// GetBeanName 由bean的具体类型派生默认beanName：指针类型取其指向类型的名称，首字母小写
+func GetBeanName(bean interface{}) string {
+	t := reflect.TypeOf(bean)
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return lowerFirst(t.Name())
+}
+
+// IsPtr 判断bean是否为指针类型
+func IsPtr(bean interface{}) bool {
+	t := reflect.TypeOf(bean)
+	return t != nil && t.Kind() == reflect.Ptr
+}
+
+func lowerFirst(s string) string {
+	if s == "" {
+		return s
+	}
+	r := []rune(s)
+	r[0] = unicode.ToLower(r[0])
+	return string(r)
+}
@@ -5,17 +5,25 @@ import (
 	"fmt"
 	"github.com/cheivin/di/van"
 	"reflect"
-	"unsafe"
+	"sync"
 )
 
 type (
 	DI struct {
-		beanDefinitionMap map[string]definition  // beanName:bean定义
-		prototypeMap      map[string]interface{} // beanName:初始化的bean
-		beanMap           map[string]interface{} // beanName:bean实例
+		beanDefinitionMap map[string]definition      // beanName:bean定义
+		prototypeMap      map[string]interface{}     // beanName:初始化的bean
+		beanMap           map[string]interface{}     // beanName:bean实例
+		scopeMap          map[string]Scope           // beanName:作用域，未登记时默认为ScopeSingleton
+		building          map[string]bool            // beanName:原型bean构建中标记，用于循环依赖检测
+		orderedBeanNames  []string                   // 按Provide/RegisterBean调用顺序记录的beanName，用于类型查找的确定性排序
+		typeIndex         map[reflect.Type][]string  // 类型查找缓存
+		ctorMap           map[string]*ctorDefinition // beanName:通过ProvideFunc注册的构造函数
+		conditionMap      map[string]Condition       // beanName:通过ProvideIf注册的条件
+		profiles          map[string]bool            // 已激活的profile集合
 		loaded            bool
 		unsafe            bool
 		valueStore        ValueStore
+		mu                sync.Mutex // 保护Load()完成后仍会被并发变更的beanMap/building/typeIndex（LoadAndServe启动的Serve goroutine可能并发解析依赖）
 	}
 
 	// BeanConstruct Bean实例创建时
@@ -49,6 +57,12 @@ func New() *DI {
 		beanDefinitionMap: map[string]definition{},
 		prototypeMap:      map[string]interface{}{},
 		beanMap:           map[string]interface{}{},
+		scopeMap:          map[string]Scope{},
+		building:          map[string]bool{},
+		typeIndex:         map[reflect.Type][]string{},
+		ctorMap:           map[string]*ctorDefinition{},
+		conditionMap:      map[string]Condition{},
+		profiles:          map[string]bool{},
 		valueStore:        van.New(),
 	}
 }
@@ -77,7 +91,11 @@ func (di *DI) RegisterNamedBean(beanName string, bean interface{}) *DI {
 	if _, exist := di.beanMap[beanName]; exist {
 		panic(fmt.Errorf("%w: bean %s already exists", ErrBean, beanName))
 	}
+	if _, exist := di.ctorMap[beanName]; exist {
+		panic(fmt.Errorf("%w: bean %s already defined by constructor", ErrDefinition, beanName))
+	}
 	di.beanMap[beanName] = bean
+	di.orderedBeanNames = append(di.orderedBeanNames, beanName)
 	return di
 }
 
@@ -109,12 +127,17 @@ func (di *DI) ProvideWithBeanName(beanName string, beanType interface{}) *DI {
 	if _, exist := di.beanMap[beanName]; exist {
 		panic(fmt.Errorf("%w: bean %s already exists", ErrBean, beanName))
 	}
+	// 检查是否已被ProvideFunc注册为构造函数bean
+	if _, exist := di.ctorMap[beanName]; exist {
+		panic(fmt.Errorf("%w: bean %s already defined by constructor", ErrDefinition, beanName))
+	}
+	di.orderedBeanNames = append(di.orderedBeanNames, beanName)
 	return di
 }
 
+// GetBean 获取指定名称的bean。单例/懒单例返回共享实例，原型(ScopePrototype)每次调用都会构建一个新实例
 func (di *DI) GetBean(beanName string) (interface{}, bool) {
-	bean, ok := di.beanMap[beanName]
-	return bean, ok
+	return di.resolveDependency(beanName, false)
 }
 
 func (di *DI) UseValueStore(v ValueStore) {
@@ -129,15 +152,25 @@ func (di *DI) Load() {
 	if di.loaded {
 		panic(ErrLoaded)
 	}
+	// 第一阶段：在完整的bean定义集合上求值Condition，过滤掉不满足条件的定义
+	di.applyConditions()
+	// 第二阶段：按既有流程实例化、注入、执行生命周期回调
 	di.initializeBean()
+	// buildConstructors先于aware执行，使经典Provide()单例在注入阶段可以解析到
+	// 已构建完成的ProvideFunc bean（resolveDependency对尚未实例化的ctorMap bean无从构建）
+	di.buildConstructors()
 	di.aware()
 	di.initialized()
 }
 
 // initializeBean 初始化bean对象
 func (di *DI) initializeBean() {
-	// 创建类型的指针对象
+	// 创建类型的指针对象，原型(ScopePrototype)与懒单例(ScopeLazySingleton)不在此阶段创建，
+	// 而是在首次GetBean/被注入时按需构建
 	for beanName, def := range di.beanDefinitionMap {
+		if scope := di.scopeOf(beanName); scope == ScopePrototype || scope == ScopeLazySingleton {
+			continue
+		}
 		prototype := reflect.New(def.Type).Interface()
 		di.prototypeMap[beanName] = prototype
 	}
@@ -159,55 +192,8 @@ func (di *DI) aware() {
 		def := di.beanDefinitionMap[beanName]
 		bean := reflect.ValueOf(prototype).Elem()
 		for filedName, awareInfo := range def.awareMap {
-			var awareBean interface{}
-			var ok bool
-			if awareBean, ok = di.beanMap[awareInfo.beanName]; !ok {
-				// 手动注册的bean中找不到，尝试查找原型定义
-				if awareBean, ok = di.prototypeMap[awareInfo.beanName]; !ok {
-					panic(fmt.Errorf("%w: %s notfound for %s(%s.%s)",
-						ErrBean,
-						awareInfo.beanName,
-						beanName,
-						def.Type.String(),
-						filedName,
-					))
-				}
-			}
-			// 注入
-			value := reflect.ValueOf(awareBean)
-			// 类型检查
-			if awareInfo.isPtr { // 指针类型
-				if !value.Type().AssignableTo(awareInfo.beanType) {
-					panic(fmt.Errorf("%w: %s(%s) not match for %s(%s.%s) need type %s",
-						ErrBean,
-						awareInfo.beanName, value.Type().String(),
-						beanName,
-						def.Type.String(),
-						filedName,
-						awareInfo.beanType.String(),
-					))
-				}
-			} else { // 接口类型
-				if !value.Type().Implements(awareInfo.beanType) {
-					panic(fmt.Errorf("%w: %s(%s) not implements interface %s for %s(%s.%s)",
-						ErrBean,
-						awareInfo.beanName, value.Type().String(),
-						awareInfo.beanType.String(),
-						beanName,
-						def.Type.String(),
-						filedName,
-					))
-				}
-			}
-
-			// 设置值
-			if di.unsafe {
-				field := bean.FieldByName(filedName)
-				field = reflect.NewAt(field.Type(), unsafe.Pointer(field.UnsafeAddr())).Elem()
-				field.Set(value)
-			} else {
-				bean.FieldByName(filedName).Set(value)
-			}
+			// 单个bean按名称/作用域解析；[]Interface、map[string]Interface形式的字段按类型收集
+			di.injectAwareField(beanName, def, bean, filedName, awareInfo)
 		}
 		// 注入后方法
 		if propertiesSet, ok := prototype.(AfterPropertiesSet); ok {
@@ -0,0 +1,86 @@
+package di
+
+import (
+	"errors"
+	"testing"
+)
+
+type ctorOrderingDep struct {
+	value string
+}
+
+func newCtorOrderingDep() *ctorOrderingDep {
+	return &ctorOrderingDep{value: "from-ctor"}
+}
+
+type classicBeanDependingOnCtor struct {
+	Dep *ctorOrderingDep `aware:""`
+}
+
+func TestLoad_ClassicSingletonCanDependOnProvideFuncBean(t *testing.T) {
+	container := New()
+	container.Provide(&classicBeanDependingOnCtor{})
+	container.ProvideFunc("ctorOrderingDep", newCtorOrderingDep)
+	container.Load()
+
+	bean, ok := container.GetBean("classicBeanDependingOnCtor")
+	if !ok {
+		t.Fatalf("expected classicBeanDependingOnCtor to be resolvable")
+	}
+	classic := bean.(*classicBeanDependingOnCtor)
+	if classic.Dep == nil {
+		t.Fatalf("expected aware-injected dependency to be built before aware() runs, got nil")
+	}
+	if classic.Dep.value != "from-ctor" {
+		t.Fatalf("expected injected dependency to come from the constructor, got %q", classic.Dep.value)
+	}
+}
+
+func TestLoad_CollectionInjectionIncludesProvideFuncBeans(t *testing.T) {
+	container := New()
+	container.ProvideFunc("fooGreeterCtor", func() *fooGreeter { return &fooGreeter{} })
+	container.RegisterBean(&barGreeter{})
+	container.Provide(&greeterCollector{})
+	container.Load()
+
+	bean, ok := container.GetBean("greeterCollector")
+	if !ok {
+		t.Fatalf("expected greeterCollector to be resolvable")
+	}
+	collector := bean.(*greeterCollector)
+	if len(collector.All) != 2 {
+		t.Fatalf("expected constructor-provided bean to participate in collection injection, got %d beans", len(collector.All))
+	}
+}
+
+type ctorNameCollisionTarget struct{}
+
+func TestRegisterNamedBean_PanicsOnNameAlreadyUsedByProvideFunc(t *testing.T) {
+	defer func() {
+		r := recover()
+		if r == nil {
+			t.Fatalf("expected panic when registering a classic bean under a ProvideFunc name")
+		}
+		if err, ok := r.(error); !ok || !errors.Is(err, ErrDefinition) {
+			t.Fatalf("expected panic to wrap ErrDefinition, got %v", r)
+		}
+	}()
+	container := New()
+	container.ProvideFunc("shared", func() *ctorNameCollisionTarget { return &ctorNameCollisionTarget{} })
+	container.RegisterNamedBean("shared", &ctorNameCollisionTarget{})
+}
+
+func TestProvideWithBeanName_PanicsOnNameAlreadyUsedByProvideFunc(t *testing.T) {
+	defer func() {
+		r := recover()
+		if r == nil {
+			t.Fatalf("expected panic when registering a classic bean definition under a ProvideFunc name")
+		}
+		if err, ok := r.(error); !ok || !errors.Is(err, ErrDefinition) {
+			t.Fatalf("expected panic to wrap ErrDefinition, got %v", r)
+		}
+	}()
+	container := New()
+	container.ProvideFunc("shared", func() *ctorNameCollisionTarget { return &ctorNameCollisionTarget{} })
+	container.ProvideWithBeanName("shared", &ctorNameCollisionTarget{})
+}
@@ -0,0 +1,111 @@
+// Package grpcx 将gRPC服务实现与HTTP处理器接入到di容器：调用方通过RegisterService/RegisterHTTPHandler
+// 以手动bean的方式注册已构建好的实现（与container.RegisterBean语义一致，不参与aware注入与生命周期回调——
+// 调用方需要自行完成实现的依赖装配），grpcx负责注册一个经由ProvideFunc构建的内部glue bean，
+// 在Load()时按构造函数依赖解析出*grpc.Server/*http.ServeMux，把已登记的实现绑定上去。
+package grpcx
+
+import (
+	"net/http"
+
+	"github.com/cheivin/di"
+	"google.golang.org/grpc"
+)
+
+// HTTPHandler 可自动挂载到*http.ServeMux bean的bean需要实现的接口
+type HTTPHandler interface {
+	Pattern() string
+	ServeHTTP(w http.ResponseWriter, r *http.Request)
+}
+
+const (
+	grpcRegistrarBeanName = "di.grpcx.grpcRegistrar"
+	httpRegistrarBeanName = "di.grpcx.httpRegistrar"
+	grpcBinderBeanName    = "di.grpcx.grpcBinder"
+	httpBinderBeanName    = "di.grpcx.httpBinder"
+)
+
+type serviceBinding struct {
+	desc *grpc.ServiceDesc
+	impl interface{}
+}
+
+// grpcRegistrar 以手动bean的方式挂在容器上，纯粹累积RegisterService登记的服务实现，自身不做任何绑定
+type grpcRegistrar struct {
+	bindings []serviceBinding
+}
+
+// grpcBinder 通过ProvideFunc注册的glue bean：构造函数按类型解析出grpcRegistrar与*grpc.Server，
+// 在构建完成后的AfterPropertiesSet中把已登记的服务实现绑定到*grpc.Server bean上
+type grpcBinder struct {
+	registrar *grpcRegistrar
+	server    *grpc.Server
+}
+
+func newGrpcBinder(registrar *grpcRegistrar, server *grpc.Server) *grpcBinder {
+	return &grpcBinder{registrar: registrar, server: server}
+}
+
+func (b *grpcBinder) AfterPropertiesSet() {
+	for _, binding := range b.registrar.bindings {
+		b.server.RegisterService(binding.desc, binding.impl)
+	}
+}
+
+// httpRegistrar 以手动bean的方式挂在容器上，纯粹累积RegisterHTTPHandler登记的处理器，自身不做任何绑定
+type httpRegistrar struct {
+	handlers []HTTPHandler
+}
+
+// httpBinder 通过ProvideFunc注册的glue bean：构造函数按类型解析出httpRegistrar与*http.ServeMux，
+// 在构建完成后的AfterPropertiesSet中把已登记的HTTPHandler挂载到*http.ServeMux bean上
+type httpBinder struct {
+	registrar *httpRegistrar
+	mux       *http.ServeMux
+}
+
+func newHTTPBinder(registrar *httpRegistrar, mux *http.ServeMux) *httpBinder {
+	return &httpBinder{registrar: registrar, mux: mux}
+}
+
+func (b *httpBinder) AfterPropertiesSet() {
+	for _, handler := range b.registrar.handlers {
+		b.mux.Handle(handler.Pattern(), handler)
+	}
+}
+
+func grpcRegistrarOf(container *di.DI) *grpcRegistrar {
+	if bean, ok := container.GetBean(grpcRegistrarBeanName); ok {
+		return bean.(*grpcRegistrar)
+	}
+	r := &grpcRegistrar{}
+	container.RegisterNamedBean(grpcRegistrarBeanName, r)
+	container.ProvideFunc(grpcBinderBeanName, newGrpcBinder)
+	return r
+}
+
+func httpRegistrarOf(container *di.DI) *httpRegistrar {
+	if bean, ok := container.GetBean(httpRegistrarBeanName); ok {
+		return bean.(*httpRegistrar)
+	}
+	r := &httpRegistrar{}
+	container.RegisterNamedBean(httpRegistrarBeanName, r)
+	container.ProvideFunc(httpBinderBeanName, newHTTPBinder)
+	return r
+}
+
+// RegisterService 将已构建好的impl以手动bean的方式注册到容器（等同于container.RegisterBean，
+// 不参与aware注入/生命周期回调，调用前需自行完成impl的依赖装配），并在Load()时
+// 自动调用容器中*grpc.Server bean的RegisterService(desc, impl)完成服务绑定
+func RegisterService(container *di.DI, impl interface{}, desc grpc.ServiceDesc) {
+	container.RegisterBean(impl)
+	registrar := grpcRegistrarOf(container)
+	registrar.bindings = append(registrar.bindings, serviceBinding{desc: &desc, impl: impl})
+}
+
+// RegisterHTTPHandler 将已构建好的handler以手动bean的方式注册到容器（等同于container.RegisterBean，
+// 不参与aware注入/生命周期回调），并在Load()时自动挂载到*http.ServeMux bean上
+func RegisterHTTPHandler(container *di.DI, handler HTTPHandler) {
+	container.RegisterBean(handler)
+	registrar := httpRegistrarOf(container)
+	registrar.handlers = append(registrar.handlers, handler)
+}
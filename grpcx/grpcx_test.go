@@ -0,0 +1,63 @@
+package grpcx
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/cheivin/di"
+	"google.golang.org/grpc"
+)
+
+type greetServiceImpl struct{}
+
+var greetServiceDesc = grpc.ServiceDesc{
+	ServiceName: "grpcx_test.Greet",
+	HandlerType: (*any)(nil),
+}
+
+type pingHandler struct{ called bool }
+
+func (h *pingHandler) Pattern() string { return "/ping" }
+func (h *pingHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	h.called = true
+	w.WriteHeader(http.StatusOK)
+}
+
+// TestRegisterService_BindsImplToGRPCServerOnLoad 验证RegisterService登记的实现在Load()后
+// 真正绑定到了容器里的*grpc.Server bean上
+func TestRegisterService_BindsImplToGRPCServerOnLoad(t *testing.T) {
+	container := di.New()
+	server := grpc.NewServer()
+	container.RegisterBean(server)
+	impl := &greetServiceImpl{}
+	RegisterService(container, impl, greetServiceDesc)
+	container.Load()
+
+	info := server.GetServiceInfo()
+	if _, ok := info["grpcx_test.Greet"]; !ok {
+		t.Fatalf("expected service %q to be registered on the *grpc.Server bean", "grpcx_test.Greet")
+	}
+}
+
+// TestRegisterHTTPHandler_BindsHandlerToServeMuxOnLoad 验证RegisterHTTPHandler登记的处理器
+// 在Load()后真正挂载到了容器里的*http.ServeMux bean上
+func TestRegisterHTTPHandler_BindsHandlerToServeMuxOnLoad(t *testing.T) {
+	container := di.New()
+	mux := http.NewServeMux()
+	container.RegisterBean(mux)
+	handler := &pingHandler{}
+	RegisterHTTPHandler(container, handler)
+	container.Load()
+
+	req := httptest.NewRequest(http.MethodGet, "/ping", nil)
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, req)
+
+	if !handler.called {
+		t.Fatalf("expected handler registered via RegisterHTTPHandler to be invoked through the *http.ServeMux bean")
+	}
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", rec.Code)
+	}
+}
@@ -0,0 +1,69 @@
+package di
+
+import (
+	"fmt"
+	"reflect"
+	"unsafe"
+)
+
+// injectAwareField 根据awareInfo将依赖注入到bean的对应字段，单个bean/bean集合(slice)/bean集合(map)三种场景统一入口
+func (di *DI) injectAwareField(beanName string, def definition, bean reflect.Value, filedName string, awareInfo awareInfo) {
+	switch awareInfo.collection {
+	case collectionSlice:
+		di.setField(bean, filedName, di.collectBeansByType(awareInfo.beanType))
+	case collectionMap:
+		di.setField(bean, filedName, di.collectBeansByTypeAsMap(awareInfo.beanType))
+	default:
+		di.setField(bean, filedName, di.resolveAwareValue(beanName, def, filedName, awareInfo))
+	}
+}
+
+// resolveAwareValue 解析单个bean字段的注入值，并做指针/接口类型校验
+func (di *DI) resolveAwareValue(beanName string, def definition, filedName string, awareInfo awareInfo) reflect.Value {
+	awareBean, ok := di.resolveDependency(awareInfo.beanName, awareInfo.scope == ScopePrototype)
+	if !ok {
+		panic(fmt.Errorf("%w: %s notfound for %s(%s.%s)",
+			ErrBean,
+			awareInfo.beanName,
+			beanName,
+			def.Type.String(),
+			filedName,
+		))
+	}
+	value := reflect.ValueOf(awareBean)
+	if awareInfo.isPtr { // 指针类型
+		if !value.Type().AssignableTo(awareInfo.beanType) {
+			panic(fmt.Errorf("%w: %s(%s) not match for %s(%s.%s) need type %s",
+				ErrBean,
+				awareInfo.beanName, value.Type().String(),
+				beanName,
+				def.Type.String(),
+				filedName,
+				awareInfo.beanType.String(),
+			))
+		}
+	} else { // 接口类型
+		if !value.Type().Implements(awareInfo.beanType) {
+			panic(fmt.Errorf("%w: %s(%s) not implements interface %s for %s(%s.%s)",
+				ErrBean,
+				awareInfo.beanName, value.Type().String(),
+				awareInfo.beanType.String(),
+				beanName,
+				def.Type.String(),
+				filedName,
+			))
+		}
+	}
+	return value
+}
+
+// setField 将解析出的值写入目标字段，unsafe模式下允许写入未导出字段
+func (di *DI) setField(bean reflect.Value, filedName string, value reflect.Value) {
+	if di.unsafe {
+		field := bean.FieldByName(filedName)
+		field = reflect.NewAt(field.Type(), unsafe.Pointer(field.UnsafeAddr())).Elem()
+		field.Set(value)
+	} else {
+		bean.FieldByName(filedName).Set(value)
+	}
+}
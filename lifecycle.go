@@ -0,0 +1,104 @@
+package di
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Serve 长期运行的bean（如HTTP服务、消息消费者）。LoadAndServe会为每个实现该接口的bean
+// 启动一个独立的goroutine运行其Serve方法
+type Serve interface {
+	Serve(ctx context.Context) error
+}
+
+// Disposable 需要在容器关闭时释放资源的bean
+type Disposable interface {
+	Destroy() error
+}
+
+// DefaultShutdownTimeout LoadAndServe关闭时等待所有Destroy完成的默认超时时间
+const DefaultShutdownTimeout = 10 * time.Second
+
+// LoadAndServe 执行Load()后，为每个实现Serve的bean启动独立goroutine运行；当ctx被取消或
+// 任意Serve返回非nil错误时，按注册顺序的逆序对所有Disposable bean调用Destroy()并返回聚合后的错误
+func (di *DI) LoadAndServe(ctx context.Context) error {
+	return di.LoadAndServeWithTimeout(ctx, DefaultShutdownTimeout)
+}
+
+// LoadAndServeWithTimeout 与LoadAndServe相同，但允许自定义Destroy阶段的超时时间
+func (di *DI) LoadAndServeWithTimeout(ctx context.Context, shutdownTimeout time.Duration) error {
+	di.Load()
+
+	serveCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	errCh := make(chan error, 1)
+	serving := 0
+	for _, beanName := range di.orderedBeanNames {
+		// beanMap的读取需要与已启动的Serve goroutine内部触发的懒单例/原型构建同步（它们会并发写beanMap）
+		di.mu.Lock()
+		bean, ok := di.beanMap[beanName]
+		di.mu.Unlock()
+		if !ok {
+			continue
+		}
+		serve, ok := bean.(Serve)
+		if !ok {
+			continue
+		}
+		serving++
+		go func(name string, serve Serve) {
+			if err := serve.Serve(serveCtx); err != nil {
+				select {
+				case errCh <- fmt.Errorf("bean %s: %w", name, err):
+				default:
+				}
+			}
+		}(beanName, serve)
+	}
+
+	var serveErr error
+	if serving > 0 {
+		select {
+		case <-ctx.Done():
+		case serveErr = <-errCh:
+		}
+	} else {
+		<-ctx.Done()
+	}
+	cancel()
+
+	return errors.Join(serveErr, di.destroy(shutdownTimeout))
+}
+
+// destroy 按注册顺序(orderedBeanNames)的逆序调用所有Disposable bean的Destroy方法，聚合所有返回的错误。
+// 该顺序不跟踪实际的实例化顺序：注册较晚、却因被依赖而先构建的bean（如ProvideFunc bean）会先于依赖它的bean被Destroy
+func (di *DI) destroy(timeout time.Duration) error {
+	done := make(chan error, 1)
+	go func() {
+		var errs []error
+		for i := len(di.orderedBeanNames) - 1; i >= 0; i-- {
+			beanName := di.orderedBeanNames[i]
+			di.mu.Lock()
+			bean, ok := di.beanMap[beanName]
+			di.mu.Unlock()
+			if !ok {
+				continue
+			}
+			if disposable, ok := bean.(Disposable); ok {
+				if err := disposable.Destroy(); err != nil {
+					errs = append(errs, fmt.Errorf("bean %s: %w", beanName, err))
+				}
+			}
+		}
+		done <- errors.Join(errs...)
+	}()
+	select {
+	case err := <-done:
+		return err
+	case <-time.After(timeout):
+		return fmt.Errorf("%w: shutdown timed out after %s", ErrBean, timeout)
+	}
+}
@@ -0,0 +1,58 @@
+package di
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+type raceLazyTarget struct{}
+
+type raceServeBean struct {
+	container *DI
+	resolved  chan *raceLazyTarget
+}
+
+func (b *raceServeBean) Serve(ctx context.Context) error {
+	bean, ok := b.container.GetBean("raceLazyTarget")
+	if ok {
+		b.resolved <- bean.(*raceLazyTarget)
+	} else {
+		b.resolved <- nil
+	}
+	<-ctx.Done()
+	return nil
+}
+
+// TestLoadAndServe_ConcurrentServeBeansResolveSameLazySingletonSafely 用-race运行：两个Serve bean
+// 在各自goroutine中同时首次解析同一个懒单例，resolveDependency对beanMap的写入必须经过同步，
+// 否则会触发concurrent map writes
+func TestLoadAndServe_ConcurrentServeBeansResolveSameLazySingletonSafely(t *testing.T) {
+	container := New()
+	resolvedA := make(chan *raceLazyTarget, 1)
+	resolvedB := make(chan *raceLazyTarget, 1)
+	container.ProvideScoped("raceLazyTarget", &raceLazyTarget{}, ScopeLazySingleton)
+	container.ProvideFunc("raceServeA", func() *raceServeBean {
+		return &raceServeBean{container: container, resolved: resolvedA}
+	})
+	container.ProvideFunc("raceServeB", func() *raceServeBean {
+		return &raceServeBean{container: container, resolved: resolvedB}
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	errCh := make(chan error, 1)
+	go func() { errCh <- container.LoadAndServeWithTimeout(ctx, time.Second) }()
+
+	a := <-resolvedA
+	b := <-resolvedB
+	cancel()
+	if err := <-errCh; err != nil {
+		t.Fatalf("unexpected error from LoadAndServeWithTimeout: %v", err)
+	}
+	if a == nil || b == nil {
+		t.Fatalf("expected both Serve beans to resolve the lazy singleton")
+	}
+	if a != b {
+		t.Fatalf("expected both Serve beans to observe the same lazy singleton instance, got %p and %p", a, b)
+	}
+}
@@ -0,0 +1,116 @@
+package di
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// Scope Bean的作用域
+type Scope int
+
+const (
+	// ScopeSingleton 单例，默认作用域，容器加载时创建一次，全局复用同一实例
+	ScopeSingleton Scope = iota
+	// ScopePrototype 原型，每次GetBean或被注入时都会创建一个新实例
+	ScopePrototype
+	// ScopeLazySingleton 懒加载单例，首次被GetBean或注入时才创建，此后复用同一实例
+	ScopeLazySingleton
+)
+
+// ProvideScoped 以指定作用域注册bean原型
+func (di *DI) ProvideScoped(beanName string, prototype interface{}, scope Scope) *DI {
+	if beanName == "" {
+		beanName = GetBeanName(prototype)
+	}
+	di.ProvideWithBeanName(beanName, prototype)
+	di.scopeMap[beanName] = scope
+	return di
+}
+
+// scopeOf 返回beanName注册时声明的作用域，未声明时默认为单例
+func (di *DI) scopeOf(beanName string) Scope {
+	return di.scopeMap[beanName]
+}
+
+// resolveDependency 解析一个依赖bean，按其作用域决定是复用已有实例还是按需构建
+// forceFresh 用于支持注入字段上的 scope:"prototype" 标签：即使依赖bean本身是单例注册的，
+// 该次注入仍然构建一个全新的实例。
+// beanMap/prototypeMap的读写都经由di.mu同步：LoadAndServeWithTimeout启动的Serve bean可能
+// 在各自goroutine里并发解析同一个懒单例/原型依赖，map本身不是并发安全的
+func (di *DI) resolveDependency(beanName string, forceFresh bool) (interface{}, bool) {
+	if !forceFresh {
+		di.mu.Lock()
+		bean, ok := di.beanMap[beanName]
+		di.mu.Unlock()
+		if ok {
+			return bean, true
+		}
+	}
+	def, isDefinition := di.beanDefinitionMap[beanName]
+	if !isDefinition {
+		// 非beanDefinitionMap登记的bean（手动注册的）不支持原型构建，直接按现有逻辑回退
+		di.mu.Lock()
+		bean, ok := di.prototypeMap[beanName]
+		di.mu.Unlock()
+		if ok {
+			return bean, true
+		}
+		return nil, false
+	}
+	switch {
+	case forceFresh || di.scopeOf(beanName) == ScopePrototype:
+		return di.buildBean(beanName, def), true
+	case di.scopeOf(beanName) == ScopeLazySingleton:
+		bean := di.buildBean(beanName, def)
+		di.mu.Lock()
+		di.beanMap[beanName] = bean
+		di.mu.Unlock()
+		return bean, true
+	default:
+		// 单例在Load流程中已经放入prototypeMap等待注入
+		di.mu.Lock()
+		bean, ok := di.prototypeMap[beanName]
+		di.mu.Unlock()
+		if ok {
+			return bean, true
+		}
+		return nil, false
+	}
+}
+
+// buildBean 构建一个全新的bean实例：实例化、注入依赖、触发生命周期回调
+// 原型bean不会被写入beanMap，每次调用都会重新执行该流程。building标记的读写经由di.mu同步，
+// 循环依赖检测本身不能因为并发构建两个互不相关的原型bean而误报或漏报
+func (di *DI) buildBean(beanName string, def definition) interface{} {
+	di.mu.Lock()
+	if di.building[beanName] {
+		di.mu.Unlock()
+		panic(fmt.Errorf("%w: circular dependency building prototype bean %s", ErrBean, beanName))
+	}
+	di.building[beanName] = true
+	di.mu.Unlock()
+	defer func() {
+		di.mu.Lock()
+		delete(di.building, beanName)
+		di.mu.Unlock()
+	}()
+
+	prototype := reflect.New(def.Type).Interface()
+	if construct, ok := prototype.(BeanConstruct); ok {
+		construct.BeanConstruct()
+	}
+	if preInitialize, ok := prototype.(PreInitialize); ok {
+		preInitialize.PreInitialize()
+	}
+	bean := reflect.ValueOf(prototype).Elem()
+	for fieldName, awareInfo := range def.awareMap {
+		di.injectAwareField(beanName, def, bean, fieldName, awareInfo)
+	}
+	if propertiesSet, ok := prototype.(AfterPropertiesSet); ok {
+		propertiesSet.AfterPropertiesSet()
+	}
+	if initialized, ok := prototype.(Initialized); ok {
+		initialized.Initialized()
+	}
+	return prototype
+}
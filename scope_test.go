@@ -0,0 +1,114 @@
+package di
+
+import "testing"
+
+type scopeTestCounter struct {
+	builds int
+}
+
+type prototypeBean struct {
+	Counter *scopeTestCounter `aware:""`
+	id      int
+}
+
+func (b *prototypeBean) AfterPropertiesSet() {
+	b.Counter.builds++
+	b.id = b.Counter.builds
+}
+
+type lazyBean struct {
+	builds int
+}
+
+func (b *lazyBean) BeanConstruct() {
+	b.builds++
+}
+
+type forcedFreshTarget struct {
+	Counter *scopeTestCounter `aware:""`
+	id      int
+}
+
+func (b *forcedFreshTarget) AfterPropertiesSet() {
+	b.Counter.builds++
+	b.id = b.Counter.builds
+}
+
+type singletonConsumer struct {
+	Target *forcedFreshTarget `aware:"" scope:"prototype"`
+}
+
+func newScopeTestDI() *DI {
+	container := New()
+	container.RegisterBean(&scopeTestCounter{})
+	return container
+}
+
+func TestPrototypeScope_BuildsFreshInstanceEachCall(t *testing.T) {
+	container := newScopeTestDI()
+	container.ProvideScoped("prototypeBean", &prototypeBean{}, ScopePrototype)
+	container.Load()
+
+	first, ok := container.GetBean("prototypeBean")
+	if !ok {
+		t.Fatalf("expected prototypeBean to be resolvable")
+	}
+	second, ok := container.GetBean("prototypeBean")
+	if !ok {
+		t.Fatalf("expected prototypeBean to be resolvable")
+	}
+	if first == second {
+		t.Fatalf("expected prototype scope to return distinct instances, got same pointer")
+	}
+	if first.(*prototypeBean).id == second.(*prototypeBean).id {
+		t.Fatalf("expected each prototype instance to be freshly constructed")
+	}
+}
+
+func TestLazySingleton_NotBuiltUntilFirstAccess(t *testing.T) {
+	container := New()
+	container.ProvideScoped("lazyBean", &lazyBean{}, ScopeLazySingleton)
+	container.Load()
+	if _, built := container.beanMap["lazyBean"]; built {
+		t.Fatalf("expected lazy singleton not to be built during Load")
+	}
+
+	bean, ok := container.GetBean("lazyBean")
+	if !ok {
+		t.Fatalf("expected lazyBean to be resolvable")
+	}
+	if bean.(*lazyBean).builds != 1 {
+		t.Fatalf("expected lazy singleton to be built exactly once on first access, got %d builds", bean.(*lazyBean).builds)
+	}
+
+	again, ok := container.GetBean("lazyBean")
+	if !ok {
+		t.Fatalf("expected lazyBean to be resolvable")
+	}
+	if bean != again {
+		t.Fatalf("expected lazy singleton to return the same instance on subsequent calls")
+	}
+}
+
+func TestScopeTag_ForcesFreshInstanceAtInjectionSite(t *testing.T) {
+	container := newScopeTestDI()
+	container.Provide(&forcedFreshTarget{})
+	container.Provide(&singletonConsumer{})
+	container.Load()
+
+	shared, ok := container.GetBean("forcedFreshTarget")
+	if !ok {
+		t.Fatalf("expected forcedFreshTarget to be resolvable as a singleton")
+	}
+	consumerBean, ok := container.GetBean("singletonConsumer")
+	if !ok {
+		t.Fatalf("expected singletonConsumer to be resolvable")
+	}
+	injected := consumerBean.(*singletonConsumer).Target
+	if injected == shared {
+		t.Fatalf("expected scope:\"prototype\" injection site to receive a freshly built instance, got the shared singleton")
+	}
+	if injected.id == shared.(*forcedFreshTarget).id {
+		t.Fatalf("expected the forced-fresh instance to have gone through its own build")
+	}
+}
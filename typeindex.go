@@ -0,0 +1,121 @@
+package di
+
+import "reflect"
+
+// collectionKind 描述aware字段是否为bean集合，以及集合的形态
+type collectionKind int
+
+const (
+	// collectionNone 普通的单个bean字段
+	collectionNone collectionKind = iota
+	// collectionSlice []SomeInterface 形式的字段，按注册顺序收集所有实现SomeInterface的bean
+	collectionSlice
+	// collectionMap map[string]SomeInterface 形式的字段，key为beanName
+	collectionMap
+)
+
+// GetByType 按类型查找一个bean，beanType传入类型的零值指针，例如 (*SomeInterface)(nil) 或 (*SomeStruct)(nil)。
+// 当存在多个实现匹配时，返回按注册顺序的第一个
+func (di *DI) GetByType(beanType interface{}) (interface{}, bool) {
+	beans := di.GetBeansByType(beanType)
+	if len(beans) == 0 {
+		return nil, false
+	}
+	return beans[0], true
+}
+
+// GetBeansByType 返回所有匹配beanType的bean，按注册顺序排列
+func (di *DI) GetBeansByType(beanType interface{}) []interface{} {
+	names := di.beanNamesByType(indirectType(beanType))
+	beans := make([]interface{}, 0, len(names))
+	for _, beanName := range names {
+		if bean, ok := di.resolveDependency(beanName, false); ok {
+			beans = append(beans, bean)
+		}
+	}
+	return beans
+}
+
+// indirectType 将类型令牌还原为用于匹配的reflect.Type：(*SomeInterface)(nil) 还原为接口类型本身，
+// (*SomeStruct)(nil) 保持为指针类型*SomeStruct，与容器中bean的具体(指针)类型一致
+func indirectType(beanType interface{}) reflect.Type {
+	t := reflect.TypeOf(beanType)
+	if t.Kind() == reflect.Ptr && t.Elem().Kind() == reflect.Interface {
+		return t.Elem()
+	}
+	return t
+}
+
+// beanNamesByType 返回注册时类型与beanType匹配(接口类型按Implements判断，具体类型按AssignableTo判断)的beanName列表，
+// 保持注册顺序；结果会被缓存在typeIndex中。typeIndex的读写经由di.mu同步，避免Load()完成后
+// GetByType/GetBeansByType被多个Serve goroutine并发调用时对同一个未缓存类型产生并发写
+func (di *DI) beanNamesByType(t reflect.Type) []string {
+	di.mu.Lock()
+	names, ok := di.typeIndex[t]
+	di.mu.Unlock()
+	if ok {
+		return names
+	}
+	for _, beanName := range di.orderedBeanNames {
+		concrete := di.concreteType(beanName)
+		if concrete == nil {
+			continue
+		}
+		if t.Kind() == reflect.Interface {
+			if concrete.Implements(t) {
+				names = append(names, beanName)
+			}
+		} else if concrete == t {
+			names = append(names, beanName)
+		}
+	}
+	di.mu.Lock()
+	di.typeIndex[t] = names
+	di.mu.Unlock()
+	return names
+}
+
+// concreteType 返回beanName对应bean的具体(指针)类型
+func (di *DI) concreteType(beanName string) reflect.Type {
+	di.mu.Lock()
+	bean, ok := di.beanMap[beanName]
+	di.mu.Unlock()
+	if ok {
+		return reflect.TypeOf(bean)
+	}
+	if def, ok := di.beanDefinitionMap[beanName]; ok {
+		return reflect.PtrTo(def.Type)
+	}
+	if ctorDef, ok := di.ctorMap[beanName]; ok {
+		return ctorDef.fn.Type().Out(0)
+	}
+	return nil
+}
+
+// collectBeansByType 构建 []beanType 的reflect.Value，按注册顺序填充所有匹配的bean
+func (di *DI) collectBeansByType(elemType reflect.Type) reflect.Value {
+	names := di.beanNamesByType(elemType)
+	slice := reflect.MakeSlice(reflect.SliceOf(elemType), 0, len(names))
+	for _, beanName := range names {
+		bean, ok := di.resolveDependency(beanName, false)
+		if !ok {
+			continue
+		}
+		slice = reflect.Append(slice, reflect.ValueOf(bean))
+	}
+	return slice
+}
+
+// collectBeansByTypeAsMap 构建 map[string]beanType 的reflect.Value，key为beanName
+func (di *DI) collectBeansByTypeAsMap(elemType reflect.Type) reflect.Value {
+	names := di.beanNamesByType(elemType)
+	m := reflect.MakeMapWithSize(reflect.MapOf(reflect.TypeOf(""), elemType), len(names))
+	for _, beanName := range names {
+		bean, ok := di.resolveDependency(beanName, false)
+		if !ok {
+			continue
+		}
+		m.SetMapIndex(reflect.ValueOf(beanName), reflect.ValueOf(bean))
+	}
+	return m
+}
@@ -0,0 +1,80 @@
+package di
+
+import "testing"
+
+type typeIndexGreeter interface {
+	Greet() string
+}
+
+type fooGreeter struct{}
+
+func (*fooGreeter) Greet() string { return "foo" }
+
+type barGreeter struct{}
+
+func (*barGreeter) Greet() string { return "bar" }
+
+type greeterCollector struct {
+	All    []typeIndexGreeter          `aware:""`
+	ByName map[string]typeIndexGreeter `aware:""`
+}
+
+func TestGetBeansByType_OrderedByRegistration(t *testing.T) {
+	container := New()
+	container.RegisterBean(&fooGreeter{})
+	container.ProvideWithBeanName("barGreeter", &barGreeter{})
+	container.Load()
+
+	beans := container.GetBeansByType((*typeIndexGreeter)(nil))
+	if len(beans) != 2 {
+		t.Fatalf("expected 2 beans implementing typeIndexGreeter, got %d", len(beans))
+	}
+	if _, ok := beans[0].(*fooGreeter); !ok {
+		t.Fatalf("expected registration order to put fooGreeter first, got %T", beans[0])
+	}
+	if _, ok := beans[1].(*barGreeter); !ok {
+		t.Fatalf("expected registration order to put barGreeter second, got %T", beans[1])
+	}
+}
+
+func TestGetByType_ReturnsFirstMatch(t *testing.T) {
+	container := New()
+	container.RegisterBean(&fooGreeter{})
+	container.Load()
+
+	bean, ok := container.GetByType((*typeIndexGreeter)(nil))
+	if !ok {
+		t.Fatalf("expected a bean implementing typeIndexGreeter")
+	}
+	if _, ok := bean.(*fooGreeter); !ok {
+		t.Fatalf("expected fooGreeter, got %T", bean)
+	}
+}
+
+func TestCollectionInjection_SliceAndMapByType(t *testing.T) {
+	container := New()
+	container.RegisterBean(&fooGreeter{})
+	container.ProvideWithBeanName("barGreeter", &barGreeter{})
+	container.Provide(&greeterCollector{})
+	container.Load()
+
+	bean, ok := container.GetBean("greeterCollector")
+	if !ok {
+		t.Fatalf("expected greeterCollector to be resolvable")
+	}
+	collector := bean.(*greeterCollector)
+
+	if len(collector.All) != 2 {
+		t.Fatalf("expected 2 greeters injected into slice field, got %d", len(collector.All))
+	}
+	if collector.All[0].Greet() != "foo" || collector.All[1].Greet() != "bar" {
+		t.Fatalf("expected slice injection in registration order, got %v", collector.All)
+	}
+
+	if len(collector.ByName) != 2 {
+		t.Fatalf("expected 2 greeters injected into map field, got %d", len(collector.ByName))
+	}
+	if collector.ByName["fooGreeter"] == nil || collector.ByName["barGreeter"] == nil {
+		t.Fatalf("expected map injection keyed by beanName, got %v", collector.ByName)
+	}
+}